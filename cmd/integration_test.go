@@ -5,12 +5,15 @@ import (
 	"encoding/json"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.k6.io/k6/control"
 	"go.k6.io/k6/lib/testutils"
 )
 
@@ -104,6 +107,168 @@ func TestRelativeLogPathWithSetupAndTeardown(t *testing.T) {
 	assert.Equal(t, "bar\nfoo\nfoo\nbaz\n", string(logContents))
 }
 
+func TestMultipleLogOutputsWithDifferentFormats(t *testing.T) {
+	t.Parallel()
+
+	ts := newGlobalTestState(t)
+
+	jsonLogPath := filepath.Join(ts.cwd, "json.log")
+	rawLogPath := filepath.Join(ts.cwd, "raw.log")
+
+	ts.args = []string{
+		"k6", "--quiet",
+		"--log-output", "file=" + jsonLogPath + ",format=json",
+		"--log-output", "file=" + rawLogPath + ",format=raw",
+		"run", "--no-summary", "-",
+	}
+	ts.stdIn = bytes.NewBufferString(fooLogDefaultFunc)
+	newRootCommand(ts.globalState).execute()
+
+	assert.True(t, testutils.LogContains(ts.loggerHook.Drain(), logrus.InfoLevel, `foo`))
+	assert.Empty(t, ts.stdErr.Bytes())
+	assert.Empty(t, ts.stdOut.Bytes())
+
+	jsonContents, err := afero.ReadFile(ts.fs, jsonLogPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(jsonContents), `"msg":"foo"`)
+
+	rawContents, err := afero.ReadFile(ts.fs, rawLogPath)
+	require.NoError(t, err)
+	assert.Equal(t, "foo\n", string(rawContents))
+}
+
+func TestLogOutputPerSinkFilter(t *testing.T) {
+	t.Parallel()
+
+	ts := newGlobalTestState(t)
+
+	fooLogPath := filepath.Join(ts.cwd, "foo.log")
+	allLogPath := filepath.Join(ts.cwd, "all.log")
+
+	ts.args = []string{
+		"k6",
+		"--log-output", "file=" + fooLogPath + ",format=raw,filter=^foo",
+		"--log-output", "file=" + allLogPath + ",format=raw",
+		"run", "-i", "2", "-",
+	}
+	ts.stdIn = bytes.NewBufferString(fooLogDefaultFunc + `
+		export function setup() { console.log('bar'); };
+		export function teardown() { console.log('baz'); };
+	`)
+	newRootCommand(ts.globalState).execute()
+
+	// The filtered sink only ever sees the messages matching its filter...
+	fooContents, err := afero.ReadFile(ts.fs, fooLogPath)
+	require.NoError(t, err)
+	assert.Equal(t, "foo\nfoo\n", string(fooContents))
+
+	// ...while the unfiltered sink is unaffected and sees everything.
+	allContents, err := afero.ReadFile(ts.fs, allLogPath)
+	require.NoError(t, err)
+	assert.Equal(t, "bar\nfoo\nfoo\nbaz\n", string(allContents))
+}
+
+func TestLogOutputPerSinkLevel(t *testing.T) {
+	t.Parallel()
+
+	ts := newGlobalTestState(t)
+
+	debugLogPath := filepath.Join(ts.cwd, "debug.log")
+	warnLogPath := filepath.Join(ts.cwd, "warn.log")
+
+	ts.args = []string{
+		"k6",
+		"--log-output", "file=" + debugLogPath + ",format=raw,level=debug",
+		"--log-output", "file=" + warnLogPath + ",format=raw,level=warn",
+		"run", "-i", "1", "-",
+	}
+	// console.log() is forwarded at info level, so it must show up in the
+	// debug sink but be gated out of the warn-and-above sink.
+	ts.stdIn = bytes.NewBufferString(fooLogDefaultFunc)
+	newRootCommand(ts.globalState).execute()
+
+	debugContents, err := afero.ReadFile(ts.fs, debugLogPath)
+	require.NoError(t, err)
+	assert.Equal(t, "foo\n", string(debugContents))
+
+	warnContents, err := afero.ReadFile(ts.fs, warnLogPath)
+	require.NoError(t, err)
+	assert.Empty(t, warnContents)
+}
+
+func TestVerboseRaisesLevelOnEverySink(t *testing.T) {
+	t.Parallel()
+
+	ts := newGlobalTestState(t)
+
+	logPath := filepath.Join(ts.cwd, "test.log")
+	ts.args = []string{
+		"k6", "--verbose",
+		"--log-output", "file=" + logPath + ",format=raw",
+		"run", "--no-summary", "-",
+	}
+	ts.stdIn = bytes.NewBufferString(`
+		export default function() {
+			console.debug('quux');
+		};
+	`)
+	newRootCommand(ts.globalState).execute()
+
+	// --verbose lowers the sink's default level to debug, so a debug-level
+	// console.debug() now reaches the file even though no level= was given.
+	logContents, err := afero.ReadFile(ts.fs, logPath)
+	require.NoError(t, err)
+	assert.Equal(t, "quux\n", string(logContents))
+}
+
+func TestVerboseDoesNotOverridePerSinkLevel(t *testing.T) {
+	t.Parallel()
+
+	ts := newGlobalTestState(t)
+
+	logPath := filepath.Join(ts.cwd, "test.log")
+	ts.args = []string{
+		"k6", "--verbose",
+		"--log-output", "file=" + logPath + ",format=raw,level=warn",
+		"run", "--no-summary", "-",
+	}
+	ts.stdIn = bytes.NewBufferString(`
+		export default function() {
+			console.debug('quux');
+		};
+	`)
+	newRootCommand(ts.globalState).execute()
+
+	// An explicit level= on the sink still wins over the --verbose default.
+	logContents, err := afero.ReadFile(ts.fs, logPath)
+	require.NoError(t, err)
+	assert.Empty(t, logContents)
+}
+
+func TestLogFilterDropsNonMatchingMessages(t *testing.T) {
+	t.Parallel()
+
+	ts := newGlobalTestState(t)
+
+	logPath := filepath.Join(ts.cwd, "test.log")
+	ts.args = []string{
+		"k6", "--log-filter", "^foo",
+		"--log-output", "file=" + logPath + ",format=raw",
+		"run", "-i", "2", "-",
+	}
+	ts.stdIn = bytes.NewBufferString(fooLogDefaultFunc + `
+		export function setup() { console.log('bar'); };
+		export function teardown() { console.log('baz'); };
+	`)
+	newRootCommand(ts.globalState).execute()
+
+	// The global --log-filter drops bar/baz before they ever reach the sink,
+	// even though the sink itself has no filter= of its own.
+	logContents, err := afero.ReadFile(ts.fs, logPath)
+	require.NoError(t, err)
+	assert.Equal(t, "foo\nfoo\n", string(logContents))
+}
+
 func TestWrongCliFlagIterations(t *testing.T) {
 	t.Parallel()
 
@@ -229,9 +394,182 @@ func TestMetricsAndThresholds(t *testing.T) {
 	require.Equal(t, expected, teardownThresholds)
 }
 
+func TestSummaryLogRecord(t *testing.T) {
+	t.Parallel()
+	script := `
+		import { Counter } from 'k6/metrics';
+
+		var setupCounter = new Counter('setup_counter');
+		var teardownCounter = new Counter('teardown_counter');
+
+		export const options = {
+			thresholds: {
+				'setup_counter': ['count == 1'],
+				'teardown_counter': ['count == 1'],
+			},
+		};
+
+		export function setup() { setupCounter.add(1); }
+		export default function () {}
+		export function teardown() { teardownCounter.add(1); }
+	`
+	ts := newGlobalTestState(t)
+	require.NoError(t, afero.WriteFile(ts.fs, filepath.Join(ts.cwd, "test.js"), []byte(script), 0o644))
+	ts.args = []string{"k6", "run", "--quiet", "--summary-log", "--no-summary", "test.js"}
+
+	newRootCommand(ts.globalState).execute()
+
+	logEntries := ts.loggerHook.Drain()
+	require.Len(t, logEntries, 1)
+	require.Equal(t, "summary", logEntries[0].Data["type"])
+
+	var summary map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(logEntries[0].Message), &summary))
+
+	metrics, ok := summary["metrics"].(map[string]interface{})
+	require.True(t, ok)
+
+	for _, name := range []string{"setup_counter", "teardown_counter"} {
+		counter, ok := metrics[name].(map[string]interface{})
+		require.True(t, ok, "missing metric %s", name)
+
+		thresholds, ok := counter["thresholds"].(map[string]interface{})
+		require.True(t, ok, "missing thresholds for %s", name)
+
+		expected := map[string]interface{}{"count == 1": map[string]interface{}{"ok": true}}
+		require.Equal(t, expected, thresholds)
+
+		values, ok := counter["values"].(map[string]interface{})
+		require.True(t, ok, "missing values for %s", name)
+		assert.EqualValues(t, 1, values["count"])
+	}
+}
+
+func TestSummaryLogOffByDefault(t *testing.T) {
+	t.Parallel()
+
+	ts := newGlobalTestState(t)
+	ts.args = []string{"k6", "--quiet", "run", "--no-summary", "-"}
+	ts.stdIn = bytes.NewBufferString(noopDefaultFunc)
+	newRootCommand(ts.globalState).execute()
+
+	assert.Empty(t, ts.loggerHook.Drain())
+}
+
+// fakeControlHandler stands in for the real schedulerControlHandler, so that
+// status/pause/resume can be exercised against a bare control.Server without
+// needing a full `k6 run` behind it.
+type fakeControlHandler struct {
+	mu     sync.Mutex
+	paused bool
+}
+
+func newFakeControlHandler() *fakeControlHandler {
+	return &fakeControlHandler{}
+}
+
+func (h *fakeControlHandler) Status() (control.StatusData, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return control.StatusData{Paused: h.paused, VUs: 1, VUsMax: 1}, nil
+}
+
+func (h *fakeControlHandler) Pause() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.paused = true
+	return nil
+}
+
+func (h *fakeControlHandler) Resume() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.paused = false
+	return nil
+}
+
+func (h *fakeControlHandler) Scale(control.ScaleArgs) error { return nil }
+
+func (h *fakeControlHandler) SetupData() (json.RawMessage, error) {
+	return json.RawMessage(`{}`), nil
+}
+
+func (h *fakeControlHandler) MetricsSnapshot() (json.RawMessage, error) {
+	return json.RawMessage(`{}`), nil
+}
+
+func (h *fakeControlHandler) Stop() error { return nil }
+
+func TestControlStatusPauseResumeAgainstFakeHandler(t *testing.T) {
+	t.Parallel()
+
+	addr := filepath.Join(t.TempDir(), "control.sock")
+	ln, err := control.Listen(addr)
+	require.NoError(t, err)
+	defer func() { _ = ln.Close() }()
+
+	srv := control.NewServer(ln, newFakeControlHandler(), nil)
+	go func() { _ = srv.Serve() }()
+
+	ts := newGlobalTestState(t)
+	ts.args = []string{"k6", "pause", "--address", addr}
+	newRootCommand(ts.globalState).execute()
+	assert.Empty(t, ts.stdErr.Bytes())
+
+	ts = newGlobalTestState(t)
+	ts.args = []string{"k6", "status", "--address", addr}
+	newRootCommand(ts.globalState).execute()
+	assert.Contains(t, ts.stdOut.String(), `"paused": true`)
+
+	ts = newGlobalTestState(t)
+	ts.args = []string{"k6", "resume", "--address", addr}
+	newRootCommand(ts.globalState).execute()
+
+	ts = newGlobalTestState(t)
+	ts.args = []string{"k6", "status", "--address", addr}
+	newRootCommand(ts.globalState).execute()
+	assert.Contains(t, ts.stdOut.String(), `"paused": false`)
+}
+
+func TestControlPausedRunResumedByAnotherProcess(t *testing.T) {
+	t.Parallel()
+
+	controlAddr := filepath.Join(t.TempDir(), "control.sock")
+
+	runTs := newGlobalTestState(t)
+	runTs.args = []string{
+		"k6", "run", "--paused", "--control-addr", controlAddr,
+		"-i", "10", "-",
+	}
+	runTs.stdIn = bytes.NewBufferString(noopDefaultFunc)
+
+	runDone := make(chan struct{})
+	go func() {
+		defer close(runDone)
+		newRootCommand(runTs.globalState).execute()
+	}()
+
+	// Wait for the control socket to come up before trying to resume.
+	require.Eventually(t, func() bool {
+		client, err := control.NewClient(controlAddr)
+		if err != nil {
+			return false
+		}
+		defer func() { _ = client.Close() }()
+		status, err := client.Status()
+		return err == nil && status.Paused
+	}, 5*time.Second, 10*time.Millisecond)
+
+	resumeTs := newGlobalTestState(t)
+	resumeTs.args = []string{"k6", "resume", "--address", controlAddr}
+	newRootCommand(resumeTs.globalState).execute()
+	assert.Empty(t, resumeTs.stdErr.Bytes())
+
+	<-runDone
+
+	stdOut := runTs.stdOut.String()
+	assert.Contains(t, stdOut, "10 complete and 0 interrupted iterations")
+}
+
 // TODO: add a hell of a lot more integration tests, including some that spin up
 // a test HTTP server and actually check if k6 hits it
-
-// TODO: also add a test that starts multiple k6 "instances", for example:
-//  - one with `k6 run --paused` and another with `k6 resume`
-//  - one with `k6 run` and another with `k6 stats` or `k6 status`
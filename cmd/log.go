@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// logSinkSpec is the parsed form of a single --log-output value, e.g.
+// `file=run.log,format=json,level=debug` or `loki=http://example.com,filter=http_req`.
+//
+// The first comma-separated token selects the sink type (and, for sinks that
+// need one, its location); every token after that is a `key=value` option
+// that overrides the sink's format, level or message filter.
+type logSinkSpec struct {
+	raw      string
+	typ      string
+	location string
+
+	format   string // "" means fall back to the global --log-format
+	level    logrus.Level
+	levelSet bool // whether level= was explicitly given, as opposed to defaulted
+	filter   *regexp.Regexp
+}
+
+// parseLogOutputArg splits a single --log-output argument into a sink type,
+// an optional location and its `key=value` options.
+func parseLogOutputArg(arg string) (logSinkSpec, error) {
+	spec := logSinkSpec{raw: arg, level: logrus.InfoLevel}
+
+	parts := strings.Split(arg, ",")
+	typeAndLoc := strings.SplitN(parts[0], "=", 2)
+	spec.typ = typeAndLoc[0]
+	if len(typeAndLoc) == 2 {
+		spec.location = typeAndLoc[1]
+	}
+
+	for _, opt := range parts[1:] {
+		kv := strings.SplitN(opt, "=", 2)
+		if len(kv) != 2 {
+			return spec, fmt.Errorf("invalid --log-output option %q, expected key=value", opt)
+		}
+		key, value := kv[0], kv[1]
+		switch key {
+		case "format":
+			spec.format = value
+		case "level":
+			lvl, err := logrus.ParseLevel(value)
+			if err != nil {
+				return spec, fmt.Errorf("invalid level %q for --log-output %q: %w", value, arg, err)
+			}
+			spec.level = lvl
+			spec.levelSet = true
+		case "filter":
+			re, err := regexp.Compile(value)
+			if err != nil {
+				return spec, fmt.Errorf("invalid filter %q for --log-output %q: %w", value, arg, err)
+			}
+			spec.filter = re
+		default:
+			return spec, fmt.Errorf("unknown --log-output option %q in %q", key, arg)
+		}
+	}
+
+	return spec, nil
+}
+
+// logSinkHook is a logrus.Hook that writes to a single configured sink,
+// applying that sink's own level gate and optional message filter before
+// handing the formatted entry to its writer.
+type logSinkHook struct {
+	out       io.Writer
+	formatter logrus.Formatter
+	level     logrus.Level
+	filter    *regexp.Regexp
+}
+
+// newLogSinkHook builds the hook for a single sink. verbose raises the
+// sink's default level to debug, unless the sink has its own explicit
+// level=... option, which always wins.
+func newLogSinkHook(spec logSinkSpec, out io.Writer, defaultFormat string, verbose bool) (*logSinkHook, error) {
+	format := spec.format
+	if format == "" {
+		format = defaultFormat
+	}
+	formatter, err := parseLogFormat(format)
+	if err != nil {
+		return nil, err
+	}
+
+	level := spec.level
+	if verbose && !spec.levelSet {
+		level = logrus.DebugLevel
+	}
+
+	return &logSinkHook{out: out, formatter: formatter, level: level, filter: spec.filter}, nil
+}
+
+// Levels returns every level this sink's own level= setting lets through;
+// multiLogHook.Fire consults it directly instead of comparing entry.Level
+// against h.level itself, so there's one place that decides whether a sink
+// sees a given entry.
+func (h *logSinkHook) Levels() []logrus.Level {
+	return logrus.AllLevels[:h.level+1]
+}
+
+// enabled reports whether this sink's Levels() includes level.
+func (h *logSinkHook) enabled(level logrus.Level) bool {
+	for _, l := range h.Levels() {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *logSinkHook) Fire(entry *logrus.Entry) error {
+	if h.filter != nil && !h.filter.MatchString(entry.Message) {
+		return nil
+	}
+	b, err := h.formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+	_, err = h.out.Write(b)
+	return err
+}
+
+// multiLogHook fans a single logrus entry out to any number of independently
+// configured logSinkHooks, so that e.g. two files can receive the same run's
+// logs in different formats, at different levels, with different filters.
+//
+// globalFilter, if set (from --log-filter), is applied before any per-sink
+// filter - a message it drops never reaches any sink, regardless of that
+// sink's own filter= option.
+type multiLogHook struct {
+	hooks        []*logSinkHook
+	globalFilter *regexp.Regexp
+}
+
+func (h *multiLogHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *multiLogHook) Fire(entry *logrus.Entry) error {
+	if h.globalFilter != nil && !h.globalFilter.MatchString(entry.Message) {
+		return nil
+	}
+
+	var firstErr error
+	for _, sink := range h.hooks {
+		if !sink.enabled(entry.Level) {
+			continue
+		}
+		if err := sink.Fire(entry); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// parseLogFormat resolves the --log-format / per-sink format= value to a
+// concrete logrus.Formatter.
+func parseLogFormat(format string) (logrus.Formatter, error) {
+	switch format {
+	case "", "text":
+		return &logrus.TextFormatter{}, nil
+	case "raw":
+		return &rawFormatter{}, nil
+	case "json":
+		return &logrus.JSONFormatter{}, nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q", format)
+	}
+}
+
+// rawFormatter writes just the bare log message, one per line - it's what
+// lets --log-format raw forward a script's console.log() output verbatim.
+type rawFormatter struct{}
+
+func (*rawFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	return []byte(entry.Message + "\n"), nil
+}
+
+// logSinkOpener resolves a logSinkSpec's type to a writer, and possibly an
+// io.Closer that must be closed once the test run finishes (e.g. an open file
+// handle). rootCommand.openLogSink handles file, stdout and stderr only; any
+// other type, loki included, is rejected with an error.
+type logSinkOpener func(spec logSinkSpec) (io.Writer, io.Closer, error)
+
+// logSetupConfig bundles the flags that together decide how --log-output
+// sinks get built: the raw --log-output values, the --log-format default,
+// --verbose and --log-filter.
+type logSetupConfig struct {
+	outputs       []string
+	defaultFormat string
+	verbose       bool
+	globalFilter  *regexp.Regexp
+}
+
+// setupLogSinks parses every --log-output argument, opens its destination via
+// open, and returns a single hook that fans entries out to all of them. The
+// returned closers must be closed by the caller once logging is done.
+func setupLogSinks(cfg logSetupConfig, open logSinkOpener) (logrus.Hook, []io.Closer, error) {
+	hook := &multiLogHook{globalFilter: cfg.globalFilter}
+	var closers []io.Closer
+
+	for _, output := range cfg.outputs {
+		spec, err := parseLogOutputArg(output)
+		if err != nil {
+			return nil, closers, err
+		}
+
+		out, closer, err := open(spec)
+		if err != nil {
+			return nil, closers, fmt.Errorf("couldn't set up --log-output %q: %w", spec.raw, err)
+		}
+		if closer != nil {
+			closers = append(closers, closer)
+		}
+
+		sinkHook, err := newLogSinkHook(spec, out, cfg.defaultFormat, cfg.verbose)
+		if err != nil {
+			return nil, closers, err
+		}
+		hook.hooks = append(hook.hooks, sinkHook)
+	}
+
+	return hook, closers, nil
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"go.k6.io/k6/control"
+)
+
+func getCmdPause(gs *globalState) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "pause [pid]",
+		Short: "Pause a running k6 test",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := controlAddrFromArgs(cmd, args)
+			if err != nil {
+				return err
+			}
+			client, err := control.NewClient(addr)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = client.Close() }()
+			return client.Pause()
+		},
+	}
+	c.Flags().String("address", "", "address of the control socket to connect to")
+	return c
+}
+
+func getCmdResume(gs *globalState) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "resume [pid]",
+		Short: "Resume a paused k6 test",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := controlAddrFromArgs(cmd, args)
+			if err != nil {
+				return err
+			}
+			client, err := control.NewClient(addr)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = client.Close() }()
+			return client.Resume()
+		},
+	}
+	c.Flags().String("address", "", "address of the control socket to connect to")
+	return c
+}
+
+func getCmdStop(gs *globalState) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "stop [pid]",
+		Short: "Stop a running k6 test",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := controlAddrFromArgs(cmd, args)
+			if err != nil {
+				return err
+			}
+			client, err := control.NewClient(addr)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = client.Close() }()
+			return client.Stop()
+		},
+	}
+	c.Flags().String("address", "", "address of the control socket to connect to")
+	return c
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// rootCommand wires the k6 CLI's persistent flags (logging, mostly) and the
+// full set of subcommands around a globalState.
+type rootCommand struct {
+	gs  *globalState
+	cmd *cobra.Command
+
+	closers []io.Closer
+}
+
+// newRootCommand builds the `k6` command tree.
+func newRootCommand(gs *globalState) *rootCommand {
+	root := &rootCommand{gs: gs}
+
+	cmd := &cobra.Command{
+		Use:           "k6",
+		Short:         "a next-generation load generator",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return root.setupLogging(cmd)
+		},
+	}
+
+	cmd.PersistentFlags().BoolP("quiet", "q", false, "disable progress updates and default log output")
+	cmd.PersistentFlags().StringArray("log-output", nil,
+		"logging destination, can be passed multiple times, e.g. --log-output file=run.log,format=json,level=debug")
+	cmd.PersistentFlags().String("log-format", "", "default log output format: text (default), raw or json")
+	cmd.PersistentFlags().Bool("verbose", false, "lower every --log-output sink's default level to debug")
+	cmd.PersistentFlags().String("log-filter", "", "regexp; only log messages matching it are kept, across every sink")
+
+	cmd.AddCommand(
+		getCmdRun(gs),
+		getCmdStatus(gs),
+		getCmdPause(gs),
+		getCmdResume(gs),
+		getCmdScale(gs),
+		getCmdStop(gs),
+	)
+
+	root.cmd = cmd
+	return root
+}
+
+// execute runs the command tree against the globalState's args/streams and
+// reports the result through gs.osExit, mirroring how main() would call
+// os.Exit - except in tests, osExit is faked to assert on the code instead.
+func (r *rootCommand) execute() {
+	defer r.closeLogSinks()
+
+	if len(r.gs.args) > 0 {
+		r.cmd.SetArgs(r.gs.args[1:])
+	}
+	r.cmd.SetIn(r.gs.stdIn)
+	r.cmd.SetOut(r.gs.stdOut)
+	r.cmd.SetErr(r.gs.stdErr)
+
+	exitCode := 0
+	if err := r.cmd.Execute(); err != nil {
+		r.gs.logger.Error(err)
+		exitCode = 1
+	}
+
+	if r.gs.osExit != nil {
+		r.gs.osExit(exitCode)
+	}
+}
+
+func (r *rootCommand) closeLogSinks() {
+	for _, closer := range r.closers {
+		_ = closer.Close()
+	}
+}
+
+// setupLogging builds the configured --log-output sinks (falling back to a
+// single stderr sink, unless --quiet) and attaches them to gs.logger as a
+// single fan-out hook. Whenever sinks are set up, the logger's own level is
+// set to debug - it's
+// each sink (gated by its own level=, --verbose, and --log-filter) that
+// decides what actually gets written, in setupLogSinks, so that a per-sink
+// level=debug isn't silently dropped upstream of the hook. Trace entries are
+// dropped by the logger itself and never reach any sink.
+func (r *rootCommand) setupLogging(cmd *cobra.Command) error {
+	quiet, err := cmd.Flags().GetBool("quiet")
+	if err != nil {
+		return err
+	}
+	verbose, err := cmd.Flags().GetBool("verbose")
+	if err != nil {
+		return err
+	}
+	logFormat, err := cmd.Flags().GetString("log-format")
+	if err != nil {
+		return err
+	}
+	logOutputs, err := cmd.Flags().GetStringArray("log-output")
+	if err != nil {
+		return err
+	}
+	logFilterArg, err := cmd.Flags().GetString("log-filter")
+	if err != nil {
+		return err
+	}
+
+	var globalFilter *regexp.Regexp
+	if logFilterArg != "" {
+		globalFilter, err = regexp.Compile(logFilterArg)
+		if err != nil {
+			return fmt.Errorf("invalid --log-filter: %w", err)
+		}
+	}
+
+	if len(logOutputs) == 0 {
+		if quiet {
+			return nil // nothing to log to
+		}
+		logOutputs = []string{"stderr"}
+	}
+
+	r.gs.logger.SetLevel(logrus.DebugLevel)
+
+	hook, closers, err := setupLogSinks(logSetupConfig{
+		outputs:       logOutputs,
+		defaultFormat: logFormat,
+		verbose:       verbose,
+		globalFilter:  globalFilter,
+	}, r.openLogSink)
+	if err != nil {
+		return err
+	}
+
+	r.gs.logger.AddHook(hook)
+	r.closers = append(r.closers, closers...)
+	return nil
+}
+
+// openLogSink resolves a logSinkSpec's type to a writer: "stdout"/"stderr"
+// reuse the process's (possibly faked, in tests) standard streams, "file"
+// opens a path on gs.fs, relative to gs.cwd.
+func (r *rootCommand) openLogSink(spec logSinkSpec) (io.Writer, io.Closer, error) {
+	switch spec.typ {
+	case "stdout":
+		return r.gs.stdOut, nil, nil
+	case "stderr":
+		return r.gs.stdErr, nil, nil
+	case "file":
+		path := spec.location
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(r.gs.cwd, path)
+		}
+		f, err := r.gs.fs.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND|os.O_TRUNC, 0o644)
+		if err != nil {
+			return nil, nil, err
+		}
+		return f, f, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported --log-output destination %q", spec.raw)
+	}
+}
@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	null "gopkg.in/guregu/null.v3"
+
+	"go.k6.io/k6/control"
+	"go.k6.io/k6/core"
+	"go.k6.io/k6/core/local"
+	"go.k6.io/k6/js"
+	"go.k6.io/k6/lib"
+	"go.k6.io/k6/loader"
+)
+
+// getCmdRun builds the `k6 run` command: it loads and runs a test script,
+// optionally starting it paused and/or exposing a control socket so a
+// second k6 process (`k6 status`/`pause`/`resume`/`scale`/`stop`) can drive
+// it while it runs.
+func getCmdRun(gs *globalState) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "run",
+		Short: "Start a test",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRun(gs, cmd, args[0])
+		},
+	}
+
+	c.Flags().Bool("no-summary", false, "don't show the end-of-test summary")
+	c.Flags().Int64P("vus", "u", 1, "number of virtual users")
+	c.Flags().Int64P("iterations", "i", 0, "total number of script iterations to execute across all VUs")
+	c.Flags().Bool("paused", false, "start the test paused, controlled via the control socket")
+	c.Flags().String("control-addr", "", "control socket address (defaults to one derived from this process's pid)")
+	c.Flags().Bool("summary-log", false,
+		"emit a structured end-of-test summary record through the normal logger pipeline")
+
+	return c
+}
+
+func runRun(gs *globalState, cmd *cobra.Command, scriptArg string) error {
+	src, err := loadRunSource(gs, scriptArg)
+	if err != nil {
+		return err
+	}
+
+	runner, err := js.New(gs.logger, src, loader.NewMemMapFSLoader(gs.fs, gs.cwd))
+	if err != nil {
+		return fmt.Errorf("couldn't load the test script: %w", err)
+	}
+
+	if err := applyIterationFlags(cmd, gs, runner.GetOptions()); err != nil {
+		return err
+	}
+
+	executionScheduler, err := local.NewExecutionScheduler(runner, gs.logger)
+	if err != nil {
+		return fmt.Errorf("couldn't initialize the execution scheduler: %w", err)
+	}
+
+	engine, err := core.NewEngine(executionScheduler, runner.GetOptions(), gs.logger)
+	if err != nil {
+		return fmt.Errorf("couldn't initialize the engine: %w", err)
+	}
+
+	paused, err := cmd.Flags().GetBool("paused")
+	if err != nil {
+		return err
+	}
+	if paused {
+		if err := executionScheduler.SetPaused(true); err != nil {
+			return err
+		}
+	}
+
+	controlAddr, err := cmd.Flags().GetString("control-addr")
+	if err != nil {
+		return err
+	}
+	if controlAddr == "" {
+		controlAddr = control.AddressForThisProcess()
+	}
+	closeControl, err := startControlServer(gs, controlAddr, executionScheduler)
+	if err != nil {
+		return fmt.Errorf("couldn't start the control socket: %w", err)
+	}
+	defer closeControl()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	runErr := engine.Run(ctx)
+
+	noSummary, err := cmd.Flags().GetBool("no-summary")
+	if err != nil {
+		return err
+	}
+	if !noSummary {
+		summaryData, sErr := runner.HandleSummary(engine.GetSummary())
+		if sErr != nil {
+			return sErr
+		}
+		if err := writeSummary(gs, summaryData); err != nil {
+			return err
+		}
+	}
+
+	summaryLog, err := cmd.Flags().GetBool("summary-log")
+	if err != nil {
+		return err
+	}
+	if summaryLog {
+		logSummaryRecord(gs.logger, engine.GetSummary())
+	}
+
+	return runErr
+}
+
+// loadRunSource reads the test script from the given argument, which is
+// either a path on gs.fs or "-" for gs.stdIn.
+func loadRunSource(gs *globalState, scriptArg string) (*loader.SourceData, error) {
+	if scriptArg == "-" {
+		data, err := io.ReadAll(gs.stdIn)
+		if err != nil {
+			return nil, err
+		}
+		return &loader.SourceData{URL: "-", Data: data}, nil
+	}
+	return loader.ReadSource(gs.fs, gs.cwd, scriptArg)
+}
+
+// applyIterationFlags maps the --vus/--iterations flags and the
+// K6_ITERATIONS env var onto the runner's options, the same way k6 has
+// always let simple one-off runs (no scenarios in the script) be configured
+// from the CLI instead of options.scenarios.
+func applyIterationFlags(cmd *cobra.Command, gs *globalState, opts *lib.Options) error {
+	vus, err := cmd.Flags().GetInt64("vus")
+	if err != nil {
+		return err
+	}
+	opts.VUs = null.IntFrom(vus)
+
+	iterations, err := cmd.Flags().GetInt64("iterations")
+	if err != nil {
+		return err
+	}
+	if envIterations, ok := gs.envVars["K6_ITERATIONS"]; ok {
+		n, err := strconv.ParseInt(envIterations, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid K6_ITERATIONS value %q: %w", envIterations, err)
+		}
+		iterations = n
+	}
+	if iterations > 0 {
+		opts.Iterations = null.IntFrom(iterations)
+	}
+
+	return nil
+}
+
+// writeSummary prints the default end-of-test summary to gs.stdOut, exactly
+// as handleSummary's returned `stdout` field (or k6's own default report,
+// when the script doesn't define handleSummary) always has.
+func writeSummary(gs *globalState, summaryData map[string]io.Reader) error {
+	out, ok := summaryData["stdout"]
+	if !ok {
+		return nil
+	}
+	_, err := io.Copy(gs.stdOut, out)
+	return err
+}
+
+// startControlServer opens the control socket at addr and starts serving
+// status/pause/resume/scale/stop requests against the given
+// ExecutionScheduler, returning a func to shut it down.
+func startControlServer(gs *globalState, addr string, scheduler lib.ExecutionScheduler) (func(), error) {
+	ln, err := control.Listen(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	srv := control.NewServer(ln, &schedulerControlHandler{scheduler: scheduler}, gs.logger)
+	go func() {
+		if err := srv.Serve(); err != nil {
+			gs.logger.WithError(err).Error("control socket error")
+		}
+	}()
+
+	return func() { _ = ln.Close() }, nil
+}
+
+// schedulerControlHandler adapts a running lib.ExecutionScheduler to
+// control.Handler, so that `k6 status`/`pause`/`resume`/`scale`/`stop`
+// against a real `k6 run` drive the same scheduler the engine is using,
+// rather than a test double.
+type schedulerControlHandler struct {
+	scheduler lib.ExecutionScheduler
+}
+
+func (h *schedulerControlHandler) Status() (control.StatusData, error) {
+	state := h.scheduler.GetState()
+	return control.StatusData{
+		Paused:     h.scheduler.IsPaused(),
+		VUs:        state.GetCurrentlyActiveVUsCount(),
+		VUsMax:     state.GetInitializedVUsCount(),
+		Iterations: state.GetFullIterationCount(),
+	}, nil
+}
+
+func (h *schedulerControlHandler) Pause() error {
+	return h.scheduler.SetPaused(true)
+}
+
+func (h *schedulerControlHandler) Resume() error {
+	return h.scheduler.SetPaused(false)
+}
+
+func (h *schedulerControlHandler) Scale(args control.ScaleArgs) error {
+	for _, executor := range h.scheduler.GetExecutors() {
+		if executor.GetConfig().GetName() != args.Scenario {
+			continue
+		}
+		scalable, ok := executor.(lib.LiveScalableExecutor)
+		if !ok {
+			return fmt.Errorf("scenario %q isn't externally scalable", args.Scenario)
+		}
+		return scalable.SetVUs(args.VUs)
+	}
+	return fmt.Errorf("no such scenario: %q", args.Scenario)
+}
+
+func (h *schedulerControlHandler) SetupData() (json.RawMessage, error) {
+	return json.Marshal(h.scheduler.GetRunner().GetSetupData())
+}
+
+func (h *schedulerControlHandler) MetricsSnapshot() (json.RawMessage, error) {
+	return json.Marshal(h.scheduler.GetState().GetMetricsSnapshot())
+}
+
+func (h *schedulerControlHandler) Stop() error {
+	return h.scheduler.Stop()
+}
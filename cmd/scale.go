@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.k6.io/k6/control"
+)
+
+func getCmdScale(gs *globalState) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "scale [pid]",
+		Short: "Change the number of running VUs of a running k6 test",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := controlAddrFromArgs(cmd, args)
+			if err != nil {
+				return err
+			}
+
+			vus, err := cmd.Flags().GetInt64("vus")
+			if err != nil {
+				return err
+			}
+			if vus <= 0 {
+				return fmt.Errorf("--vus must be specified and greater than 0")
+			}
+			scenario, err := cmd.Flags().GetString("scenario")
+			if err != nil {
+				return err
+			}
+
+			client, err := control.NewClient(addr)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = client.Close() }()
+
+			return client.Scale(control.ScaleArgs{Scenario: scenario, VUs: vus})
+		},
+	}
+	c.Flags().String("address", "", "address of the control socket to connect to")
+	c.Flags().String("scenario", "default", "scenario to scale")
+	c.Flags().Int64("vus", 0, "new number of VUs")
+	return c
+}
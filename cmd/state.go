@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"go.k6.io/k6/lib/testutils"
+)
+
+// globalState holds everything the root command and its subcommands need
+// that would otherwise be ambient global/OS state - the filesystem, the
+// standard streams, the process environment/args, the logger - so that all
+// of it can be faked out in tests instead of touching the real OS.
+type globalState struct {
+	fs      afero.Fs
+	cwd     string
+	args    []string
+	envVars map[string]string
+
+	stdIn          io.Reader
+	stdOut, stdErr io.Writer
+
+	logger *logrus.Logger
+
+	osExit func(code int)
+}
+
+// globalTestState wraps globalState with the extra bits only tests need:
+// buffers for stdout/stderr that can be inspected after the run, a hook that
+// captures every log entry regardless of where --log-output sent it, and the
+// exit code the test expects newRootCommand.execute() to pass to os.Exit.
+type globalTestState struct {
+	*globalState
+
+	stdOut, stdErr *bytes.Buffer
+
+	loggerHook *testutils.SimpleLogrusHook
+
+	expectedExitCode int
+}
+
+// newGlobalTestState builds a globalTestState with an in-memory filesystem
+// and buffered standard streams, ready for a test to set .args/.stdIn/
+// .envVars and call newRootCommand(ts.globalState).execute().
+func newGlobalTestState(t *testing.T) *globalTestState {
+	stdOut := new(bytes.Buffer)
+	stdErr := new(bytes.Buffer)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	hook := testutils.NewLogHook(logrus.AllLevels...)
+	logger.AddHook(hook)
+
+	gs := &globalState{
+		fs:      afero.NewMemMapFs(),
+		cwd:     "/test-cwd",
+		envVars: map[string]string{},
+		stdIn:   new(bytes.Buffer),
+		stdOut:  stdOut,
+		stdErr:  stdErr,
+		logger:  logger,
+	}
+
+	ts := &globalTestState{
+		globalState: gs,
+		stdOut:      stdOut,
+		stdErr:      stdErr,
+		loggerHook:  hook,
+	}
+	ts.osExit = func(code int) {
+		// -1 is a sentinel meaning "don't check the exit code yet", used by
+		// tests that don't have a specific exitcodes constant to assert on.
+		if ts.expectedExitCode != -1 && code != ts.expectedExitCode {
+			t.Fatalf("expected exit code %d, got %d", ts.expectedExitCode, code)
+		}
+	}
+
+	return ts
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.k6.io/k6/control"
+)
+
+// controlAddrFromArgs resolves the address of the control socket a
+// status/pause/resume/scale/stop subcommand should talk to: an explicit
+// --address flag wins, otherwise a bare pid argument is translated via
+// control.AddressForPID.
+func controlAddrFromArgs(cmd *cobra.Command, args []string) (string, error) {
+	if addr, _ := cmd.Flags().GetString("address"); addr != "" {
+		return addr, nil
+	}
+	if len(args) == 0 {
+		return "", fmt.Errorf("either --address or a k6 process pid is required")
+	}
+	var pid int
+	if _, err := fmt.Sscanf(args[0], "%d", &pid); err != nil {
+		return "", fmt.Errorf("invalid pid %q: %w", args[0], err)
+	}
+	return control.AddressForPID(pid), nil
+}
+
+func getCmdStatus(gs *globalState) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "status [pid]",
+		Short: "Show the status of a running k6 test",
+		Long: `Show the status of a running k6 test.
+
+The test is identified either by its pid, or by the --address of its control
+socket.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := controlAddrFromArgs(cmd, args)
+			if err != nil {
+				return err
+			}
+			client, err := control.NewClient(addr)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = client.Close() }()
+
+			status, err := client.Status()
+			if err != nil {
+				return err
+			}
+			enc, err := json.MarshalIndent(status, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(gs.stdOut, string(enc))
+			return nil
+		},
+	}
+	c.Flags().String("address", "", "address of the control socket to connect to")
+	return c
+}
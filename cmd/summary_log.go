@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"encoding/json"
+
+	"github.com/sirupsen/logrus"
+)
+
+// logSummaryRecord emits the end-of-test structured statistics record at
+// info level through the normal logger pipeline, so it flows through every
+// configured --log-output sink the same way any other log line does. It's
+// gated behind --summary-log so it doesn't show up unless a user actually
+// wants to ship it somewhere for offline aggregation across CI runs.
+//
+// summaryData is the same JSON-able report that `k6 run` hands to
+// handleSummary, so the record carries the same metrics/thresholds shape
+// handleSummary already sees.
+func logSummaryRecord(logger logrus.FieldLogger, summaryData map[string]interface{}) {
+	b, err := json.Marshal(summaryData)
+	if err != nil {
+		logger.WithError(err).Error("couldn't build --summary-log record")
+		return
+	}
+	logger.WithField("type", "summary").Info(string(b))
+}
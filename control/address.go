@@ -0,0 +1,9 @@
+package control
+
+import "os"
+
+// AddressForThisProcess is the address a running `k6 run` uses by default,
+// unless --control-addr overrides it.
+func AddressForThisProcess() string {
+	return AddressForPID(os.Getpid())
+}
@@ -0,0 +1,103 @@
+package control
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+)
+
+// Client is a thin synchronous wrapper around a single control-socket
+// connection - enough for the short-lived `k6 status`/`k6 pause`/... CLI
+// commands, which send one request and exit.
+type Client struct {
+	conn net.Conn
+}
+
+// NewClient dials an already-open control socket/pipe address, as returned
+// by AddressForPID.
+func NewClient(addr string) (*Client, error) {
+	conn, err := Dial(addr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't connect to k6 control socket at %s: %w", addr, err)
+	}
+	return &Client{conn: conn}, nil
+}
+
+// Close closes the underlying connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) call(verb Verb, args interface{}) (Response, error) {
+	var rawArgs json.RawMessage
+	if args != nil {
+		b, err := json.Marshal(args)
+		if err != nil {
+			return Response{}, err
+		}
+		rawArgs = b
+	}
+
+	reqBytes, err := json.Marshal(Request{Verb: verb, Args: rawArgs})
+	if err != nil {
+		return Response{}, err
+	}
+	if _, err := c.conn.Write(append(reqBytes, '\n')); err != nil {
+		return Response{}, err
+	}
+
+	scanner := bufio.NewScanner(c.conn)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return Response{}, err
+		}
+		return Response{}, fmt.Errorf("control socket closed without a response to %q", verb)
+	}
+
+	var resp Response
+	if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+		return Response{}, fmt.Errorf("invalid control response: %w", err)
+	}
+	if !resp.OK {
+		return resp, fmt.Errorf("%s", resp.Error)
+	}
+	return resp, nil
+}
+
+// Status requests the running test's current status.
+func (c *Client) Status() (StatusData, error) {
+	resp, err := c.call(VerbStatus, nil)
+	if err != nil {
+		return StatusData{}, err
+	}
+	var data StatusData
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		return StatusData{}, err
+	}
+	return data, nil
+}
+
+// Pause pauses the running test.
+func (c *Client) Pause() error {
+	_, err := c.call(VerbPause, nil)
+	return err
+}
+
+// Resume resumes a paused test.
+func (c *Client) Resume() error {
+	_, err := c.call(VerbResume, nil)
+	return err
+}
+
+// Scale changes the number of VUs for a running scenario.
+func (c *Client) Scale(args ScaleArgs) error {
+	_, err := c.call(VerbScale, args)
+	return err
+}
+
+// Stop terminates the running test.
+func (c *Client) Stop() error {
+	_, err := c.call(VerbStop, nil)
+	return err
+}
@@ -0,0 +1,43 @@
+//go:build !windows
+
+package control
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+)
+
+// runtimeDir is where control socket addresses for running k6 processes
+// live, so that `k6 status <pid>` and friends can find them without the
+// user having to pass --address.
+func runtimeDir() string {
+	if dir := os.Getenv("K6_RUNTIME_DIR"); dir != "" {
+		return dir
+	}
+	return filepath.Join(os.TempDir(), "k6-control")
+}
+
+// AddressForPID returns the well-known control socket address for the k6
+// process with the given pid.
+func AddressForPID(pid int) string {
+	return filepath.Join(runtimeDir(), fmt.Sprintf("k6-%d.sock", pid))
+}
+
+// Listen opens the control endpoint at addr - a filesystem path to a unix
+// domain socket on every platform but Windows.
+func Listen(addr string) (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(addr), 0o755); err != nil {
+		return nil, err
+	}
+	// A previous k6 process that crashed without cleaning up its socket
+	// file would otherwise make every later run fail to bind here.
+	_ = os.Remove(addr)
+	return net.Listen("unix", addr)
+}
+
+// Dial connects to a control endpoint opened with Listen.
+func Dial(addr string) (net.Conn, error) {
+	return net.Dial("unix", addr)
+}
@@ -0,0 +1,31 @@
+//go:build windows
+
+package control
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/Microsoft/go-winio"
+)
+
+// AddressForPID returns the well-known control pipe address for the k6
+// process with the given pid.
+func AddressForPID(pid int) string {
+	return fmt.Sprintf(`\\.\pipe\k6-control-%d`, pid)
+}
+
+// Listen opens the control endpoint at addr - a named pipe path
+// (\\.\pipe\k6-<pid>) on Windows, since unix domain sockets aren't available.
+func Listen(addr string) (net.Listener, error) {
+	return winio.ListenPipe(addr, nil)
+}
+
+// Dial connects to a control endpoint opened with Listen.
+func Dial(addr string) (net.Conn, error) {
+	conn, err := winio.DialPipe(addr, nil)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't connect to named pipe %s: %w", addr, err)
+	}
+	return conn, nil
+}
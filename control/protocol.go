@@ -0,0 +1,56 @@
+// Package control implements the local control/IPC endpoint that a running
+// `k6 run` process exposes so that a second k6 process (`k6 status`, `k6
+// pause`, `k6 resume`, `k6 scale` or `k6 stop`) can inspect or drive it.
+//
+// The wire protocol is deliberately simple: line-delimited JSON requests and
+// responses over a unix domain socket (a named pipe on Windows). A connection
+// may carry any number of requests, each answered with one response line
+// before the next is read.
+package control
+
+import "encoding/json"
+
+// Verb identifies a control-protocol request.
+type Verb string
+
+// The set of verbs a control server understands.
+const (
+	VerbStatus          Verb = "status"
+	VerbPause           Verb = "pause"
+	VerbResume          Verb = "resume"
+	VerbScale           Verb = "scale"
+	VerbSetupData       Verb = "setup-data"
+	VerbMetricsSnapshot Verb = "metrics-snapshot"
+	VerbStop            Verb = "stop"
+)
+
+// Request is a single line-delimited JSON request sent to the control
+// socket. Args is verb-specific and left raw so Handler implementations can
+// decode it into their own types (e.g. ScaleArgs for VerbScale).
+type Request struct {
+	Verb Verb            `json:"verb"`
+	Args json.RawMessage `json:"args,omitempty"`
+}
+
+// Response is the single line-delimited JSON reply to a Request.
+type Response struct {
+	OK    bool            `json:"ok"`
+	Error string          `json:"error,omitempty"`
+	Data  json.RawMessage `json:"data,omitempty"`
+}
+
+// ScaleArgs are the Args of a VerbScale request.
+type ScaleArgs struct {
+	Scenario string `json:"scenario"`
+	VUs      int64  `json:"vus"`
+}
+
+// StatusData is the Data of a successful VerbStatus response.
+type StatusData struct {
+	Paused      bool             `json:"paused"`
+	VUs         int64            `json:"vus"`
+	VUsMax      int64            `json:"vusMax"`
+	Iterations  int64            `json:"iterations"`
+	ScenarioVUs map[string]int64 `json:"scenarioVUs,omitempty"`
+	Stopped     bool             `json:"stopped"`
+}
@@ -0,0 +1,136 @@
+package control
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Handler is implemented by whatever owns the running test (in practice,
+// cmd's run command, delegating to its lib.ExecutionScheduler) and answers
+// the verbs a control connection can send.
+type Handler interface {
+	Status() (StatusData, error)
+	Pause() error
+	Resume() error
+	Scale(args ScaleArgs) error
+	SetupData() (json.RawMessage, error)
+	MetricsSnapshot() (json.RawMessage, error)
+	Stop() error
+}
+
+// Server accepts control connections on a Listener (opened via Listen, which
+// is platform-specific - see listen_unix.go and listen_windows.go) and
+// dispatches each request line to a Handler.
+type Server struct {
+	ln      net.Listener
+	handler Handler
+	logger  logrus.FieldLogger
+}
+
+// NewServer wraps an already-open Listener; call Serve to start accepting
+// connections.
+func NewServer(ln net.Listener, handler Handler, logger logrus.FieldLogger) *Server {
+	return &Server{ln: ln, handler: handler, logger: logger}
+}
+
+// Serve accepts connections until the Listener is closed, handling each one
+// in its own goroutine. It returns nil when the Listener is closed normally.
+func (s *Server) Serve() error {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go s.handleConn(conn)
+	}
+}
+
+func (s *Server) handleConn(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		resp := s.handleLine(scanner.Bytes())
+		enc, err := json.Marshal(resp)
+		if err != nil {
+			s.logf("couldn't encode control response: %s", err)
+			return
+		}
+		if _, err := conn.Write(append(enc, '\n')); err != nil {
+			s.logf("couldn't write control response: %s", err)
+			return
+		}
+	}
+}
+
+func (s *Server) handleLine(line []byte) Response {
+	var req Request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return errResponse(fmt.Errorf("invalid control request: %w", err))
+	}
+
+	switch req.Verb {
+	case VerbStatus:
+		data, err := s.handler.Status()
+		return dataResponse(data, err)
+	case VerbPause:
+		return errResponse(s.handler.Pause())
+	case VerbResume:
+		return errResponse(s.handler.Resume())
+	case VerbScale:
+		var args ScaleArgs
+		if err := json.Unmarshal(req.Args, &args); err != nil {
+			return errResponse(fmt.Errorf("invalid scale args: %w", err))
+		}
+		return errResponse(s.handler.Scale(args))
+	case VerbSetupData:
+		data, err := s.handler.SetupData()
+		return rawDataResponse(data, err)
+	case VerbMetricsSnapshot:
+		data, err := s.handler.MetricsSnapshot()
+		return rawDataResponse(data, err)
+	case VerbStop:
+		return errResponse(s.handler.Stop())
+	default:
+		return errResponse(fmt.Errorf("unknown control verb %q", req.Verb))
+	}
+}
+
+func (s *Server) logf(format string, args ...interface{}) {
+	if s.logger != nil {
+		s.logger.Errorf(format, args...)
+	}
+}
+
+func errResponse(err error) Response {
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	return Response{OK: true}
+}
+
+func dataResponse(data StatusData, err error) Response {
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	return Response{OK: true, Data: b}
+}
+
+func rawDataResponse(data json.RawMessage, err error) Response {
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	return Response{OK: true, Data: data}
+}
@@ -0,0 +1,131 @@
+package control
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeHandler struct {
+	mu     sync.Mutex
+	paused bool
+	vus    map[string]int64
+}
+
+func newFakeHandler() *fakeHandler {
+	return &fakeHandler{vus: map[string]int64{"default": 1}}
+}
+
+func (h *fakeHandler) Status() (StatusData, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return StatusData{Paused: h.paused, VUs: h.vus["default"], ScenarioVUs: h.vus}, nil
+}
+
+func (h *fakeHandler) Pause() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.paused = true
+	return nil
+}
+
+func (h *fakeHandler) Resume() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.paused = false
+	return nil
+}
+
+func (h *fakeHandler) Scale(args ScaleArgs) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.vus[args.Scenario] = args.VUs
+	return nil
+}
+
+func (h *fakeHandler) SetupData() (json.RawMessage, error) {
+	return json.RawMessage(`{"foo":"bar"}`), nil
+}
+
+func (h *fakeHandler) MetricsSnapshot() (json.RawMessage, error) {
+	return json.RawMessage(`{}`), nil
+}
+
+func (h *fakeHandler) Stop() error {
+	return nil
+}
+
+func newTestServer(t *testing.T) (*Client, func()) {
+	t.Helper()
+
+	addr := filepath.Join(t.TempDir(), "control.sock")
+	ln, err := Listen(addr)
+	require.NoError(t, err)
+
+	handler := newFakeHandler()
+	srv := NewServer(ln, handler, nil)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_ = srv.Serve()
+	}()
+
+	client, err := NewClient(addr)
+	require.NoError(t, err)
+
+	return client, func() {
+		_ = client.Close()
+		_ = ln.Close()
+		wg.Wait()
+	}
+}
+
+func TestServerPauseResumeStatus(t *testing.T) {
+	t.Parallel()
+
+	client, cleanup := newTestServer(t)
+	defer cleanup()
+
+	status, err := client.Status()
+	require.NoError(t, err)
+	assert.False(t, status.Paused)
+
+	require.NoError(t, client.Pause())
+	status, err = client.Status()
+	require.NoError(t, err)
+	assert.True(t, status.Paused)
+
+	require.NoError(t, client.Resume())
+	status, err = client.Status()
+	require.NoError(t, err)
+	assert.False(t, status.Paused)
+}
+
+func TestServerScale(t *testing.T) {
+	t.Parallel()
+
+	client, cleanup := newTestServer(t)
+	defer cleanup()
+
+	require.NoError(t, client.Scale(ScaleArgs{Scenario: "default", VUs: 5}))
+
+	status, err := client.Status()
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, status.ScenarioVUs["default"])
+}
+
+func TestServerUnknownVerb(t *testing.T) {
+	t.Parallel()
+
+	client, cleanup := newTestServer(t)
+	defer cleanup()
+
+	_, err := client.call("bogus", nil)
+	assert.ErrorContains(t, err, "unknown control verb")
+}